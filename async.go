@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// JobStatusState is the lifecycle state of an asynchronous packing job.
+type JobStatusState string
+
+const (
+	JobQueued    JobStatusState = "queued"
+	JobRunning   JobStatusState = "running"
+	JobCompleted JobStatusState = "completed"
+	JobFailed    JobStatusState = "failed"
+	JobCanceled  JobStatusState = "canceled"
+)
+
+// Job is returned when a packing request is submitted for asynchronous
+// processing.
+type Job struct {
+	ID        string `json:"id"`
+	StatusURL string `json:"status_url"`
+}
+
+// JobStatus reports the current state of a submitted job.
+type JobStatus struct {
+	ID       string           `json:"id"`
+	State    JobStatusState   `json:"state"`
+	Progress float64          `json:"progress"`
+	Result   *PackingResponse `json:"result,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// PollOptions configures how PackAsync polls for job completion.
+type PollOptions struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Backoff     float64
+}
+
+// DefaultPollOptions returns sane polling defaults: poll every second,
+// doubling up to a 10s cap.
+func DefaultPollOptions() PollOptions {
+	return PollOptions{
+		Interval:    time.Second,
+		MaxInterval: 10 * time.Second,
+		Backoff:     2.0,
+	}
+}
+
+// SubmitPackJob submits a packing request for asynchronous processing and
+// returns a handle to poll or cancel it. Use this instead of Pack for jobs
+// large enough to risk exceeding the client's HTTP timeout.
+func (c *Client) SubmitPackJob(ctx context.Context, request *PackingRequest) (*Job, error) {
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	status, body, err := c.do(ctx, "POST", "/api/v1/pack/jobs", jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK && status != http.StatusAccepted {
+		return nil, fmt.Errorf("API returned status %d: %s", status, string(body))
+	}
+
+	var job Job
+	if err := json.Unmarshal(body, &job); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &job, nil
+}
+
+// GetJobStatus retrieves the current status of a previously submitted job.
+func (c *Client) GetJobStatus(ctx context.Context, jobID string) (*JobStatus, error) {
+	status, body, err := c.do(ctx, "GET", "/api/v1/pack/jobs/"+jobID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("job status request failed with status %d: %s", status, string(body))
+	}
+
+	var js JobStatus
+	if err := json.Unmarshal(body, &js); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &js, nil
+}
+
+// CancelJob requests cancellation of a running or queued job.
+func (c *Client) CancelJob(ctx context.Context, jobID string) error {
+	status, body, err := c.do(ctx, "DELETE", "/api/v1/pack/jobs/"+jobID, nil)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return fmt.Errorf("cancel job failed with status %d: %s", status, string(body))
+	}
+
+	return nil
+}
+
+// PackAsync submits request for asynchronous processing and polls until it
+// completes, fails, or ctx is done. On ctx cancellation it issues a best
+// effort CancelJob before returning ctx.Err().
+func (c *Client) PackAsync(ctx context.Context, request *PackingRequest, opts PollOptions) (*PackingResponse, error) {
+	job, err := c.SubmitPackJob(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			cancelCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			_ = c.CancelJob(cancelCtx, job.ID)
+			cancel()
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		status, err := c.GetJobStatus(ctx, job.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				cancelCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				_ = c.CancelJob(cancelCtx, job.ID)
+				cancel()
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		switch status.State {
+		case JobCompleted:
+			if status.Result == nil {
+				return nil, fmt.Errorf("job %s completed with no result", job.ID)
+			}
+			return status.Result, nil
+		case JobFailed:
+			return nil, fmt.Errorf("job %s failed: %s", job.ID, status.Error)
+		case JobCanceled:
+			return nil, fmt.Errorf("job %s was canceled", job.ID)
+		}
+
+		if opts.Backoff > 1 {
+			interval = time.Duration(float64(interval) * opts.Backoff)
+		}
+		if opts.MaxInterval > 0 && interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPackAsync(t *testing.T) {
+	var polls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/pack/jobs":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(Job{ID: "job-1", StatusURL: "/api/v1/pack/jobs/job-1"})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/pack/jobs/job-1":
+			polls++
+			state := JobRunning
+			var result *PackingResponse
+			if polls >= 2 {
+				state = JobCompleted
+				result = &PackingResponse{Summary: PackingSummary{TotalPallets: 1}}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(JobStatus{ID: "job-1", State: state, Result: result})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	request := &PackingRequest{
+		Cartons:           []Carton{{ID: "BOX001", Length: 1, Width: 1, Height: 1, Weight: 1, Quantity: 1}},
+		PalletConstraints: StandardPallet(),
+	}
+
+	response, err := client.PackAsync(context.Background(), request, PollOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("PackAsync failed: %v", err)
+	}
+	if response.Summary.TotalPallets != 1 {
+		t.Errorf("expected 1 pallet, got %d", response.Summary.TotalPallets)
+	}
+}
+
+func TestPackAsyncFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/pack/jobs":
+			json.NewEncoder(w).Encode(Job{ID: "job-1"})
+		case r.Method == "GET":
+			json.NewEncoder(w).Encode(JobStatus{ID: "job-1", State: JobFailed, Error: "out of memory"})
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	request := &PackingRequest{
+		Cartons:           []Carton{{ID: "BOX001", Length: 1, Width: 1, Height: 1, Weight: 1, Quantity: 1}},
+		PalletConstraints: StandardPallet(),
+	}
+
+	_, err := client.PackAsync(context.Background(), request, PollOptions{Interval: time.Millisecond})
+	if err == nil {
+		t.Fatal("expected error for failed job")
+	}
+}
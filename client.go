@@ -33,11 +33,16 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -45,6 +50,47 @@ import (
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	retry      *RetryPolicy
+	mws        []Middleware
+
+	compressLevel     int
+	compressThreshold int
+
+	localPack      Packer
+	localThreshold int
+}
+
+// Packer packs a request without making a network call. local.Pack, from
+// the client/local subpackage, satisfies this signature.
+type Packer func(request *PackingRequest) (*PackingResponse, error)
+
+// NewHybrid creates a Client that packs requests with at most threshold
+// total cartons using localPack and forwards larger requests to baseURL
+// over the network, e.g.:
+//
+//	client.NewHybrid("https://palletizer.app", 50, local.Pack)
+//
+// This avoids the round trip for small jobs, which matters most for edge
+// deployments and unit tests.
+func NewHybrid(baseURL string, threshold int, localPack Packer) *Client {
+	c := New(baseURL)
+	c.localPack = localPack
+	c.localThreshold = threshold
+	return c
+}
+
+// totalQuantity sums Quantity across a request's cartons, treating a
+// non-positive Quantity as 1 (same convention local.Pack uses).
+func totalQuantity(request *PackingRequest) int {
+	total := 0
+	for _, carton := range request.Cartons {
+		if carton.Quantity > 0 {
+			total += carton.Quantity
+		} else {
+			total++
+		}
+	}
+	return total
 }
 
 // New creates a new Palletizer API client
@@ -65,6 +111,321 @@ func NewWithHTTPClient(baseURL string, httpClient *http.Client) *Client {
 	}
 }
 
+// Option configures a Client created with NewWithOptions.
+type Option func(*Client)
+
+// WithHTTPClient sets a custom *http.Client on the Client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetry enables automatic retries of transient failures according to
+// policy. Health and Metrics requests are always retried; Pack requests are
+// retried too, since the request body is fully buffered before the first
+// attempt and can be safely resent.
+func WithRetry(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// DefaultCompressThreshold is the request body size, in bytes, above which
+// WithCompression gzips the body if no explicit threshold is given.
+const DefaultCompressThreshold = 4096
+
+// WithCompression enables gzip compression of request bodies larger than
+// threshold bytes (a non-positive threshold uses DefaultCompressThreshold),
+// compressed at level (see compress/gzip for valid levels; gzip.DefaultCompression
+// is a reasonable choice). Accept-Encoding: gzip is sent on every request
+// regardless of threshold, so gzipped responses are always transparently
+// decompressed.
+func WithCompression(level, threshold int) Option {
+	return func(c *Client) {
+		if threshold <= 0 {
+			threshold = DefaultCompressThreshold
+		}
+		c.compressLevel = level
+		c.compressThreshold = threshold
+	}
+}
+
+// NewWithOptions creates a Palletizer API client configured with opts.
+func NewWithOptions(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// RetryPolicy configures automatic retries for transient failures: network
+// errors, 502/503/504 responses, and 429 responses. Delays follow truncated
+// exponential backoff with jitter: each attempt waits
+// min(MaxInterval, InitialInterval*Multiplier^(attempt-1)), scaled by a
+// random factor in [1-Jitter/2, 1+Jitter/2].
+type RetryPolicy struct {
+	MaxAttempts     int
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Jitter          float64
+
+	// OnRetry, if set, is called before each retry sleep so callers can log
+	// or otherwise observe retry attempts.
+	OnRetry func(attempt int, err error, next time.Duration)
+}
+
+// DefaultRetryPolicy returns sane retry defaults: up to 3 attempts with an
+// initial 500ms backoff doubling up to a 10s cap and 20% jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2.0,
+		Jitter:          0.2,
+	}
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempt-1))
+	if max := float64(p.MaxInterval); p.MaxInterval > 0 && delay > max {
+		delay = max
+	}
+	if p.Jitter > 0 {
+		delay *= 1 + rand.Float64()*p.Jitter - p.Jitter/2
+	}
+	return time.Duration(delay)
+}
+
+// isRetryableStatus reports whether an HTTP status code indicates a
+// transient failure worth retrying.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses a Retry-After header, which may be either a number of
+// seconds or an HTTP-date, returning the duration to wait and whether a
+// value was present.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// RoundTrip sends a single HTTP request and returns its response. It has the
+// signature of http.Client.Do as a plain function value so Middleware can
+// wrap it.
+type RoundTrip func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTrip to add cross-cutting behavior — auth,
+// logging, tracing, request IDs — around every request the Client sends.
+// Modeled after Gin-style handler chains.
+type Middleware func(next RoundTrip) RoundTrip
+
+// Use appends middleware to the client's chain. Middleware run in the order
+// they were added: the first one passed to the first Use call is outermost
+// and is the first to see the request and the last to see the response.
+func (c *Client) Use(mws ...Middleware) {
+	c.mws = append(c.mws, mws...)
+}
+
+// roundTrip sends req through the middleware chain, with the client's
+// underlying *http.Client as the innermost RoundTrip.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	rt := RoundTrip(c.httpClient.Do)
+	for i := len(c.mws) - 1; i >= 0; i-- {
+		rt = c.mws[i](rt)
+	}
+	return rt(req)
+}
+
+// do builds and sends an HTTP request for path, running it through the
+// middleware chain and retry policy, and returns the response status and
+// drained body. Pack, Health, and Metrics all funnel through do so that
+// auth, retry, tracing, and logging compose uniformly across every
+// endpoint; each caller interprets the status and body itself, since they
+// surface errors a little differently.
+//
+// If compression is enabled and body exceeds the configured threshold, it
+// is gzipped once here, before the retry loop, so every attempt resends the
+// same compressed bytes instead of re-compressing.
+func (c *Client) do(ctx context.Context, method, path string, body []byte) (int, []byte, error) {
+	sendBody := body
+	compressed := false
+	if body != nil && c.compressThreshold > 0 && len(body) > c.compressThreshold {
+		gzipped, err := gzipCompress(body, c.compressLevel)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+		sendBody = gzipped
+		compressed = true
+	}
+
+	resp, respBody, err := c.sendWithRetry(ctx, func() (*http.Request, error) {
+		var reader io.Reader
+		if sendBody != nil {
+			reader = bytes.NewReader(sendBody)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if compressed {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
+		req.Header.Set("Accept-Encoding", "gzip")
+		return req, nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// gzipCompress returns data compressed at level.
+func gzipCompress(data []byte, level int) ([]byte, error) {
+	var buf bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gz.Write(data); err != nil {
+		gz.Close()
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gzipDecompress returns the decompressed form of gzipped data.
+func gzipDecompress(data []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return io.ReadAll(gz)
+}
+
+// sendWithRetry builds and sends a request via newReq, retrying transient
+// failures according to c.retry. newReq must be safe to call more than once;
+// callers that buffer the request body up front (as Pack does) can always
+// retry, while callers of idempotent GETs (Health, Metrics) can always retry
+// too. It returns the final response with its body already drained, so the
+// caller only needs to unmarshal it.
+func (c *Client) sendWithRetry(ctx context.Context, newReq func() (*http.Request, error)) (*http.Response, []byte, error) {
+	policy := c.retry
+	maxAttempts := 1
+	if policy != nil && policy.MaxAttempts > maxAttempts {
+		maxAttempts = policy.MaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := c.roundTrip(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to send request: %w", err)
+			if attempt == maxAttempts || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, nil, lastErr
+			}
+			if err := c.sleepBeforeRetry(ctx, policy, attempt, lastErr, nil); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response: %w", err)
+			if attempt == maxAttempts {
+				return nil, nil, lastErr
+			}
+			if err := c.sleepBeforeRetry(ctx, policy, attempt, lastErr, nil); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		if resp.Header.Get("Content-Encoding") == "gzip" {
+			decoded, err := gzipDecompress(body)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decompress response: %w", err)
+			}
+			body = decoded
+		}
+
+		if attempt < maxAttempts && isRetryableStatus(resp.StatusCode) {
+			lastErr = fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+			if err := c.sleepBeforeRetry(ctx, policy, attempt, lastErr, resp); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		return resp, body, nil
+	}
+
+	return nil, nil, lastErr
+}
+
+// sleepBeforeRetry waits out the backoff delay for attempt, honoring
+// ctx.Done(), a Retry-After header on resp if present, and policy.OnRetry.
+func (c *Client) sleepBeforeRetry(ctx context.Context, policy *RetryPolicy, attempt int, cause error, resp *http.Response) error {
+	delay := policy.backoff(attempt)
+	if resp != nil {
+		if ra, ok := retryAfter(resp); ok {
+			delay = ra
+		}
+	}
+	if policy.OnRetry != nil {
+		policy.OnRetry(attempt, cause, delay)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // Carton represents a carton to be packed
 type Carton struct {
 	ID            string  `json:"id"`
@@ -169,28 +530,24 @@ type MetricsResponse struct {
 	BuildTime      string  `json:"build_time"`
 }
 
-// Pack sends a packing request and returns the packed pallets
+// Pack sends a packing request and returns the packed pallets. The request
+// body is marshaled once up front, so it is safely retried under the
+// client's RetryPolicy, if one is configured. On a Client created with
+// NewHybrid, requests with at most the configured threshold of total
+// cartons are packed locally instead, without a network call.
 func (c *Client) Pack(ctx context.Context, request *PackingRequest) (*PackingResponse, error) {
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	if c.localPack != nil && totalQuantity(request) <= c.localThreshold {
+		return c.localPack(request)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/v1/pack", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+	jsonData, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	status, body, err := c.do(ctx, "POST", "/api/v1/pack", jsonData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
 	var response PackingResponse
@@ -198,11 +555,11 @@ func (c *Client) Pack(ctx context.Context, request *PackingRequest) (*PackingRes
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if status != http.StatusOK {
 		if response.Error != "" {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, response.Error)
+			return nil, fmt.Errorf("API error (status %d): %s", status, response.Error)
 		}
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API returned status %d: %s", status, string(body))
 	}
 
 	return &response, nil
@@ -210,23 +567,17 @@ func (c *Client) Pack(ctx context.Context, request *PackingRequest) (*PackingRes
 
 // Health checks if the API is healthy
 func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/v1/health", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	status, body, err := c.do(ctx, "GET", "/api/v1/health", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("health check failed with status %d", resp.StatusCode)
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("health check failed with status %d", status)
 	}
 
 	var health HealthResponse
-	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+	if err := json.Unmarshal(body, &health); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -235,23 +586,17 @@ func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
 
 // Metrics retrieves API metrics
 func (c *Client) Metrics(ctx context.Context) (*MetricsResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/v1/metrics", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	status, body, err := c.do(ctx, "GET", "/api/v1/metrics", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("metrics request failed with status %d", resp.StatusCode)
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("metrics request failed with status %d", status)
 	}
 
 	var metrics MetricsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+	if err := json.Unmarshal(body, &metrics); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
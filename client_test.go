@@ -1,10 +1,13 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -180,6 +183,228 @@ func TestMetrics(t *testing.T) {
 	}
 }
 
+func TestPackRetriesOnServiceUnavailable(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PackingResponse{
+			Summary: PackingSummary{TotalPallets: 1, TotalCartonsPacked: 1},
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithRetry(&RetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Multiplier:      2,
+	}))
+
+	request := &PackingRequest{
+		Cartons:           []Carton{{ID: "BOX001", Length: 1, Width: 1, Height: 1, Weight: 1, Quantity: 1}},
+		PalletConstraints: StandardPallet(),
+	}
+
+	response, err := client.Pack(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if response.Summary.TotalPallets != 1 {
+		t.Errorf("expected 1 pallet, got %d", response.Summary.TotalPallets)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestPackRetryExhausted(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithRetry(&RetryPolicy{
+		MaxAttempts:     2,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Multiplier:      2,
+	}))
+
+	request := &PackingRequest{
+		Cartons:           []Carton{{ID: "BOX001", Length: 1, Width: 1, Height: 1, Weight: 1, Quantity: 1}},
+		PalletConstraints: StandardPallet(),
+	}
+
+	_, err := client.Pack(context.Background(), request)
+	if err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClientUseOrdersMiddlewareOutermostFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(HealthResponse{Status: "healthy"})
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next RoundTrip) RoundTrip {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(req)
+			}
+		}
+	}
+
+	client := New(server.URL)
+	client.Use(trace("outer"), trace("inner"))
+
+	if _, err := client.Health(context.Background()); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("expected [outer inner], got %v", order)
+	}
+}
+
+func TestPackCompressesLargeRequestBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("expected Content-Encoding: gzip, got %q", r.Header.Get("Content-Encoding"))
+		}
+
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatalf("failed to create gzip reader: %v", err)
+		}
+		defer gz.Close()
+
+		var req PackingRequest
+		if err := json.NewDecoder(gz).Decode(&req); err != nil {
+			t.Fatalf("failed to decode gzipped request: %v", err)
+		}
+		if len(req.Cartons) == 0 {
+			t.Error("expected cartons in decoded request")
+		}
+
+		json.NewEncoder(w).Encode(PackingResponse{Summary: PackingSummary{TotalPallets: 1}})
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithCompression(gzip.DefaultCompression, 16))
+
+	cartons := make([]Carton, 0, 50)
+	for i := 0; i < 50; i++ {
+		cartons = append(cartons, Carton{ID: "BOX", Length: 1, Width: 1, Height: 1, Weight: 1, Quantity: 1})
+	}
+	request := &PackingRequest{Cartons: cartons, PalletConstraints: StandardPallet()}
+
+	if _, err := client.Pack(context.Background(), request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+}
+
+func TestPackHandlesGzipAndPlainResponses(t *testing.T) {
+	for _, gzipResponse := range []bool{true, false} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			payload, _ := json.Marshal(PackingResponse{Summary: PackingSummary{TotalPallets: 1}})
+			if gzipResponse {
+				w.Header().Set("Content-Encoding", "gzip")
+				gz := gzip.NewWriter(w)
+				gz.Write(payload)
+				gz.Close()
+				return
+			}
+			w.Write(payload)
+		}))
+
+		client := New(server.URL)
+		request := &PackingRequest{
+			Cartons:           []Carton{{ID: "BOX001", Length: 1, Width: 1, Height: 1, Weight: 1, Quantity: 1}},
+			PalletConstraints: StandardPallet(),
+		}
+
+		response, err := client.Pack(context.Background(), request)
+		server.Close()
+		if err != nil {
+			t.Fatalf("Pack failed (gzipResponse=%v): %v", gzipResponse, err)
+		}
+		if response.Summary.TotalPallets != 1 {
+			t.Errorf("expected 1 pallet (gzipResponse=%v), got %d", gzipResponse, response.Summary.TotalPallets)
+		}
+	}
+}
+
+func TestGzipCompressRoundTrip(t *testing.T) {
+	original := bytes.Repeat([]byte("palletizer"), 100)
+
+	compressed, err := gzipCompress(original, gzip.DefaultCompression)
+	if err != nil {
+		t.Fatalf("gzipCompress failed: %v", err)
+	}
+
+	decompressed, err := gzipDecompress(compressed)
+	if err != nil {
+		t.Fatalf("gzipDecompress failed: %v", err)
+	}
+	if !bytes.Equal(decompressed, original) {
+		t.Error("decompressed data does not match original")
+	}
+}
+
+func TestNewHybridDispatchesLocallyBelowThreshold(t *testing.T) {
+	var apiCalled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiCalled = true
+		json.NewEncoder(w).Encode(PackingResponse{Summary: PackingSummary{TotalPallets: 1}})
+	}))
+	defer server.Close()
+
+	var localCalled bool
+	localPack := func(request *PackingRequest) (*PackingResponse, error) {
+		localCalled = true
+		return &PackingResponse{Summary: PackingSummary{TotalPallets: 1, TotalCartonsPacked: 1}}, nil
+	}
+
+	client := NewHybrid(server.URL, 10, localPack)
+	request := &PackingRequest{
+		Cartons:           []Carton{{ID: "BOX001", Quantity: 1}},
+		PalletConstraints: StandardPallet(),
+	}
+
+	if _, err := client.Pack(context.Background(), request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if !localCalled {
+		t.Error("expected local packer to be called for a small request")
+	}
+	if apiCalled {
+		t.Error("expected API not to be called for a small request")
+	}
+
+	apiCalled, localCalled = false, false
+	request.Cartons[0].Quantity = 20
+	if _, err := client.Pack(context.Background(), request); err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if localCalled {
+		t.Error("expected local packer not to be called for a large request")
+	}
+	if !apiCalled {
+		t.Error("expected API to be called for a large request")
+	}
+}
+
 func TestStandardPallet(t *testing.T) {
 	pallet := StandardPallet()
 	if pallet.MaxLength != 1016.0 {
@@ -0,0 +1,366 @@
+// Package local implements palletization directly in Go using an Extreme
+// Point / Bottom-Left-Fill heuristic, so callers can pack offline or avoid
+// an API round trip for small jobs.
+//
+// The heuristic maintains a set of candidate "extreme points" on each
+// pallet, starting with just the pallet origin. Cartons are packed largest
+// first; for each carton, every extreme point is tried in bottom-left
+// order (lowest Z, then Y, then X), and every allowed orientation is tried
+// at that point, until one fits without colliding with an already-placed
+// carton and with enough of its base supported. Placing a carton opens up
+// to three new extreme points at its +X, +Y, and +Z corners.
+package local
+
+import (
+	"fmt"
+	"sort"
+
+	client "github.com/palletizer-app/go-sdk"
+)
+
+const epsilon = 1e-6
+
+// cartonInstance is a single physical carton expanded out of a Carton's
+// Quantity, carrying a unique ID for the placed result.
+type cartonInstance struct {
+	client.Carton
+	ID string
+}
+
+// orientation is one axis-aligned rotation a carton can be placed in.
+type orientation struct {
+	name string
+	dims client.Dimensions
+}
+
+// Pack packs req using the Extreme Point / Bottom-Left-Fill heuristic and
+// returns the same response types as the remote API, so callers can switch
+// between client.Pack and local.Pack transparently.
+func Pack(req *client.PackingRequest) (*client.PackingResponse, error) {
+	instances := expand(req.Cartons)
+	sort.Slice(instances, func(i, j int) bool {
+		vi := volume(instances[i].Carton)
+		vj := volume(instances[j].Carton)
+		if vi != vj {
+			return vi > vj
+		}
+		return instances[i].Length*instances[i].Width > instances[j].Length*instances[j].Width
+	})
+
+	pk := &packer{constraints: req.PalletConstraints, minSupport: req.PackingOptions.SupportPercentage}
+	pk.openPallet()
+
+	packed := 0
+	for _, carton := range instances {
+		if pk.place(carton) {
+			packed++
+			continue
+		}
+		// Doesn't fit on the current pallet; start a fresh one and retry
+		// once. If it still doesn't fit on an empty pallet, it's larger
+		// than the pallet itself, so it's left unpacked.
+		pk.openPallet()
+		if pk.place(carton) {
+			packed++
+		}
+	}
+	pallets := pk.finish()
+
+	var totalUtil float64
+	for _, p := range pallets {
+		totalUtil += p.UtilizationPercentage
+	}
+	avgUtil := 0.0
+	if len(pallets) > 0 {
+		avgUtil = totalUtil / float64(len(pallets))
+	}
+
+	return &client.PackingResponse{
+		Pallets: pallets,
+		Summary: client.PackingSummary{
+			TotalPallets:       len(pallets),
+			TotalCartonsPacked: packed,
+			AverageUtilization: avgUtil,
+		},
+	}, nil
+}
+
+func volume(c client.Carton) float64 {
+	return c.Length * c.Width * c.Height
+}
+
+// expand turns each Carton's Quantity into that many individual instances,
+// each with a unique carton ID.
+func expand(cartons []client.Carton) []cartonInstance {
+	var out []cartonInstance
+	for _, c := range cartons {
+		qty := c.Quantity
+		if qty <= 0 {
+			qty = 1
+		}
+		for i := 0; i < qty; i++ {
+			out = append(out, cartonInstance{Carton: c, ID: fmt.Sprintf("%s_%d", c.ID, i+1)})
+		}
+	}
+	return out
+}
+
+// orientationsFor returns the axis-aligned rotations a carton may be placed
+// in: all 6 permutations of its dimensions if AllowRotation, otherwise just
+// its original orientation.
+func orientationsFor(c client.Carton) []orientation {
+	if !c.AllowRotation {
+		return []orientation{{name: "original", dims: client.Dimensions{Length: c.Length, Width: c.Width, Height: c.Height}}}
+	}
+	return []orientation{
+		{"original", client.Dimensions{Length: c.Length, Width: c.Width, Height: c.Height}},
+		{"rotated_lw", client.Dimensions{Length: c.Width, Width: c.Length, Height: c.Height}},
+		{"rotated_lh", client.Dimensions{Length: c.Height, Width: c.Width, Height: c.Length}},
+		{"rotated_wh", client.Dimensions{Length: c.Length, Width: c.Height, Height: c.Width}},
+		{"rotated_lwh", client.Dimensions{Length: c.Width, Width: c.Height, Height: c.Length}},
+		{"rotated_hlw", client.Dimensions{Length: c.Height, Width: c.Length, Height: c.Width}},
+	}
+}
+
+// packer tracks the state of the pallet currently being filled.
+type packer struct {
+	constraints client.PalletConstraints
+	minSupport  float64
+
+	pallets []client.Pallet
+	nextID  int
+
+	placed []client.PlacedCarton
+	points []client.Point3D
+	weight float64
+}
+
+// openPallet finalizes the pallet being filled, if any, and starts a new
+// empty one.
+func (p *packer) openPallet() {
+	if len(p.placed) > 0 {
+		p.nextID++
+		p.pallets = append(p.pallets, finalize(p.nextID, p.placed, p.constraints))
+	}
+	p.placed = nil
+	p.points = []client.Point3D{{X: 0, Y: 0, Z: 0}}
+	p.weight = 0
+}
+
+// finish finalizes the current pallet, if it holds anything, and returns
+// all completed pallets.
+func (p *packer) finish() []client.Pallet {
+	if len(p.placed) > 0 {
+		p.nextID++
+		p.pallets = append(p.pallets, finalize(p.nextID, p.placed, p.constraints))
+		p.placed = nil
+	}
+	return p.pallets
+}
+
+// place tries to fit carton onto the pallet currently being filled,
+// trying every extreme point in bottom-left order and every allowed
+// orientation. It returns whether the carton was placed.
+func (p *packer) place(carton cartonInstance) bool {
+	if p.constraints.MaxWeight > 0 && p.weight+carton.Weight > p.constraints.MaxWeight+epsilon {
+		return false
+	}
+
+	sort.Slice(p.points, func(i, j int) bool {
+		a, b := p.points[i], p.points[j]
+		if a.Z != b.Z {
+			return a.Z < b.Z
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+
+	for _, pt := range p.points {
+		for _, ori := range orientationsFor(carton.Carton) {
+			if !p.fits(pt, ori.dims) {
+				continue
+			}
+			if overlapsAny(p.placed, pt, ori.dims) {
+				continue
+			}
+			if p.minSupport > 0 && supportedFraction(p.placed, pt, ori.dims) < p.minSupport-epsilon {
+				continue
+			}
+
+			p.placed = append(p.placed, client.PlacedCarton{
+				CartonID:    carton.ID,
+				Position:    pt,
+				Dimensions:  ori.dims,
+				Orientation: ori.name,
+				Weight:      carton.Weight,
+			})
+			p.weight += carton.Weight
+
+			p.points = append(removePoint(p.points, pt),
+				client.Point3D{X: pt.X + ori.dims.Length, Y: pt.Y, Z: pt.Z},
+				client.Point3D{X: pt.X, Y: pt.Y + ori.dims.Width, Z: pt.Z},
+				client.Point3D{X: pt.X, Y: pt.Y, Z: pt.Z + ori.dims.Height},
+			)
+			p.points = pruneDominated(p.points)
+			return true
+		}
+	}
+	return false
+}
+
+// fits reports whether dims placed at pt stays within the pallet's bounds.
+func (p *packer) fits(pt client.Point3D, dims client.Dimensions) bool {
+	return pt.X+dims.Length <= p.constraints.MaxLength+epsilon &&
+		pt.Y+dims.Width <= p.constraints.MaxWidth+epsilon &&
+		pt.Z+dims.Height <= p.constraints.MaxHeight+epsilon
+}
+
+// removePoint drops the first occurrence of used from points; it is
+// consumed once a carton is placed there.
+func removePoint(points []client.Point3D, used client.Point3D) []client.Point3D {
+	out := make([]client.Point3D, 0, len(points))
+	removed := false
+	for _, pt := range points {
+		if !removed && pt == used {
+			removed = true
+			continue
+		}
+		out = append(out, pt)
+	}
+	return out
+}
+
+// pruneDominated drops every point that another remaining point dominates,
+// i.e. the other point is no greater on any axis and smaller on at least
+// one. Only coordinates are compared; placed cartons are not consulted.
+func pruneDominated(points []client.Point3D) []client.Point3D {
+	kept := make([]client.Point3D, 0, len(points))
+	for i, p := range points {
+		dominated := false
+		for j, q := range points {
+			if i == j {
+				continue
+			}
+			if q.X <= p.X && q.Y <= p.Y && q.Z <= p.Z && (q.X < p.X || q.Y < p.Y || q.Z < p.Z) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// overlapsAny reports whether a carton of dims placed at pos would overlap
+// any already-placed carton.
+func overlapsAny(placed []client.PlacedCarton, pos client.Point3D, dims client.Dimensions) bool {
+	for _, p := range placed {
+		if overlaps(p, pos, dims) {
+			return true
+		}
+	}
+	return false
+}
+
+func overlaps(a client.PlacedCarton, pos client.Point3D, dims client.Dimensions) bool {
+	ax1, ay1, az1 := a.Position.X+a.Dimensions.Length, a.Position.Y+a.Dimensions.Width, a.Position.Z+a.Dimensions.Height
+	bx1, by1, bz1 := pos.X+dims.Length, pos.Y+dims.Width, pos.Z+dims.Height
+	return a.Position.X < bx1-epsilon && ax1 > pos.X+epsilon &&
+		a.Position.Y < by1-epsilon && ay1 > pos.Y+epsilon &&
+		a.Position.Z < bz1-epsilon && az1 > pos.Z+epsilon
+}
+
+// supportedFraction returns the percentage of a carton's base area, placed
+// at pos with dims, that rests on the pallet floor or the top surface of
+// cartons directly below it.
+func supportedFraction(placed []client.PlacedCarton, pos client.Point3D, dims client.Dimensions) float64 {
+	if pos.Z <= epsilon {
+		return 100.0
+	}
+	baseArea := dims.Length * dims.Width
+	if baseArea <= 0 {
+		return 100.0
+	}
+
+	var supported float64
+	for _, p := range placed {
+		top := p.Position.Z + p.Dimensions.Height
+		if top < pos.Z-epsilon || top > pos.Z+epsilon {
+			continue
+		}
+		supported += overlapArea(p, pos, dims)
+	}
+	return supported / baseArea * 100.0
+}
+
+func overlapArea(a client.PlacedCarton, pos client.Point3D, dims client.Dimensions) float64 {
+	x0, x1 := max(a.Position.X, pos.X), min(a.Position.X+a.Dimensions.Length, pos.X+dims.Length)
+	y0, y1 := max(a.Position.Y, pos.Y), min(a.Position.Y+a.Dimensions.Width, pos.Y+dims.Width)
+	if x1 <= x0 || y1 <= y0 {
+		return 0
+	}
+	return (x1 - x0) * (y1 - y0)
+}
+
+func max(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// finalize computes the summary fields (weight, height, utilization, center
+// of gravity) for a completed pallet.
+func finalize(id int, placed []client.PlacedCarton, constraints client.PalletConstraints) client.Pallet {
+	var totalWeight, totalVolume, maxHeight float64
+	var sumWX, sumWY, sumWZ float64
+
+	for _, p := range placed {
+		totalWeight += p.Weight
+		totalVolume += p.Dimensions.Length * p.Dimensions.Width * p.Dimensions.Height
+
+		cx := p.Position.X + p.Dimensions.Length/2
+		cy := p.Position.Y + p.Dimensions.Width/2
+		cz := p.Position.Z + p.Dimensions.Height/2
+		sumWX += p.Weight * cx
+		sumWY += p.Weight * cy
+		sumWZ += p.Weight * cz
+
+		if top := p.Position.Z + p.Dimensions.Height; top > maxHeight {
+			maxHeight = top
+		}
+	}
+
+	var cog client.Point3D
+	if totalWeight > 0 {
+		cog = client.Point3D{X: sumWX / totalWeight, Y: sumWY / totalWeight, Z: sumWZ / totalWeight}
+	}
+
+	var util float64
+	if palletVolume := constraints.MaxLength * constraints.MaxWidth * constraints.MaxHeight; palletVolume > 0 {
+		util = totalVolume / palletVolume * 100
+	}
+
+	cartons := make([]client.PlacedCarton, len(placed))
+	copy(cartons, placed)
+
+	return client.Pallet{
+		PalletID:              id,
+		TotalWeight:           totalWeight,
+		TotalHeight:           maxHeight,
+		UtilizationPercentage: util,
+		Cartons:               cartons,
+		CenterOfGravity:       cog,
+	}
+}
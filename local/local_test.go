@@ -0,0 +1,108 @@
+package local
+
+import (
+	"testing"
+
+	client "github.com/palletizer-app/go-sdk"
+)
+
+func TestPackSingleCarton(t *testing.T) {
+	req := &client.PackingRequest{
+		Cartons: []client.Carton{
+			{ID: "BOX001", Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 1},
+		},
+		PalletConstraints: client.StandardPallet(),
+	}
+
+	resp, err := Pack(req)
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if resp.Summary.TotalPallets != 1 {
+		t.Fatalf("expected 1 pallet, got %d", resp.Summary.TotalPallets)
+	}
+	if resp.Summary.TotalCartonsPacked != 1 {
+		t.Fatalf("expected 1 carton packed, got %d", resp.Summary.TotalCartonsPacked)
+	}
+	if resp.Pallets[0].PalletID != 1 {
+		t.Errorf("expected first pallet to have PalletID 1, got %d", resp.Pallets[0].PalletID)
+	}
+	if len(resp.Pallets[0].Cartons) != 1 {
+		t.Fatalf("expected 1 placed carton, got %d", len(resp.Pallets[0].Cartons))
+	}
+	placed := resp.Pallets[0].Cartons[0]
+	if placed.Position != (client.Point3D{X: 0, Y: 0, Z: 0}) {
+		t.Errorf("expected carton placed at origin, got %+v", placed.Position)
+	}
+}
+
+func TestPackDoesNotOverlap(t *testing.T) {
+	req := &client.PackingRequest{
+		Cartons: []client.Carton{
+			{ID: "BOX001", Length: 400, Width: 300, Height: 200, Weight: 5000, Quantity: 8},
+		},
+		PalletConstraints: client.StandardPallet(),
+	}
+
+	resp, err := Pack(req)
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+
+	for _, pallet := range resp.Pallets {
+		for i, a := range pallet.Cartons {
+			for j, b := range pallet.Cartons {
+				if i == j {
+					continue
+				}
+				if overlaps(a, b.Position, b.Dimensions) {
+					t.Errorf("cartons %s and %s overlap", a.CartonID, b.CartonID)
+				}
+			}
+		}
+	}
+}
+
+func TestPackSplitsAcrossPalletsWhenOversized(t *testing.T) {
+	constraints := client.PalletConstraints{MaxLength: 1000, MaxWidth: 1000, MaxHeight: 1000, MaxWeight: 100000}
+	req := &client.PackingRequest{
+		Cartons: []client.Carton{
+			{ID: "BOX001", Length: 900, Width: 900, Height: 900, Weight: 1000, Quantity: 3},
+		},
+		PalletConstraints: constraints,
+	}
+
+	resp, err := Pack(req)
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if resp.Summary.TotalPallets != 3 {
+		t.Errorf("expected 3 pallets (one carton each), got %d", resp.Summary.TotalPallets)
+	}
+	if resp.Summary.TotalCartonsPacked != 3 {
+		t.Errorf("expected 3 cartons packed, got %d", resp.Summary.TotalCartonsPacked)
+	}
+	for i, pallet := range resp.Pallets {
+		if pallet.PalletID != i+1 {
+			t.Errorf("expected pallet %d to have PalletID %d, got %d", i, i+1, pallet.PalletID)
+		}
+	}
+}
+
+func TestPackSkipsOversizedCarton(t *testing.T) {
+	constraints := client.PalletConstraints{MaxLength: 500, MaxWidth: 500, MaxHeight: 500, MaxWeight: 100000}
+	req := &client.PackingRequest{
+		Cartons: []client.Carton{
+			{ID: "TOOBIG", Length: 600, Width: 600, Height: 600, Weight: 1000, Quantity: 1, AllowRotation: true},
+		},
+		PalletConstraints: constraints,
+	}
+
+	resp, err := Pack(req)
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if resp.Summary.TotalCartonsPacked != 0 {
+		t.Errorf("expected oversized carton to be left unpacked, got %d packed", resp.Summary.TotalCartonsPacked)
+	}
+}
@@ -0,0 +1,219 @@
+// Package middleware provides ready-made client.Middleware implementations
+// for authentication, logging, tracing, and request IDs, so callers can
+// compose cross-cutting behavior onto a *client.Client without subclassing
+// the HTTP client.
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	client "github.com/palletizer-app/go-sdk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TokenSource returns the bearer token to attach to a request. It is called
+// on every request, so implementations that need to refresh or cache a
+// token are responsible for doing so themselves.
+type TokenSource func(req *http.Request) (string, error)
+
+// WithAuthBearer attaches an "Authorization: Bearer <token>" header using a
+// token obtained from tokenSource on every request.
+func WithAuthBearer(tokenSource TokenSource) client.Middleware {
+	return func(next client.RoundTrip) client.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			token, err := tokenSource(req)
+			if err != nil {
+				return nil, fmt.Errorf("middleware: failed to obtain bearer token: %w", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			return next(req)
+		}
+	}
+}
+
+// WithAPIKey sets header to key on every request.
+func WithAPIKey(header, key string) client.Middleware {
+	return func(next client.RoundTrip) client.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set(header, key)
+			return next(req)
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header to s on every request.
+func WithUserAgent(s string) client.Middleware {
+	return func(next client.RoundTrip) client.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("User-Agent", s)
+			return next(req)
+		}
+	}
+}
+
+// WithRequestID sets an X-Request-ID header generated by genFn on every
+// request.
+func WithRequestID(genFn func() string) client.Middleware {
+	return func(next client.RoundTrip) client.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Request-ID", genFn())
+			return next(req)
+		}
+	}
+}
+
+// Logger is the logging interface WithLogging writes to; *log.Logger
+// satisfies it.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// RedactFunc scrubs sensitive data from a request or response body before
+// it is logged.
+type RedactFunc func(body []byte) []byte
+
+// WithLogging logs the method, URL path, and status (or error) of every
+// request through logger. redactFn is not applied to anything logged here,
+// since only the method, path, and status are recorded and no bodies.
+func WithLogging(logger Logger, redactFn RedactFunc) client.Middleware {
+	return func(next client.RoundTrip) client.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil {
+				logger.Printf("palletizer: %s %s failed: %v", req.Method, req.URL.Path, err)
+				return resp, err
+			}
+			logger.Printf("palletizer: %s %s -> %d", req.Method, req.URL.Path, resp.StatusCode)
+			return resp, err
+		}
+	}
+}
+
+// operationName maps a request path to a short span name, e.g.
+// "palletizer.Pack" for POST /api/v1/pack.
+func operationName(req *http.Request) string {
+	switch {
+	case req.Method == http.MethodPost && req.URL.Path == "/api/v1/pack":
+		return "palletizer.Pack"
+	case req.URL.Path == "/api/v1/health":
+		return "palletizer.Health"
+	case req.URL.Path == "/api/v1/metrics":
+		return "palletizer.Metrics"
+	default:
+		return "palletizer." + req.Method + " " + req.URL.Path
+	}
+}
+
+// WithTracing starts a span for every request using tp, named after the
+// endpoint being called (e.g. "palletizer.Pack"). Pack requests additionally
+// get carton and pallet count attributes, read from the buffered request
+// and response bodies without disturbing the rest of the chain.
+func WithTracing(tp trace.TracerProvider) client.Middleware {
+	tracer := tp.Tracer("github.com/palletizer-app/go-sdk")
+	return func(next client.RoundTrip) client.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx, span := tracer.Start(req.Context(), operationName(req))
+			defer span.End()
+
+			isPack := req.Method == http.MethodPost && req.URL.Path == "/api/v1/pack"
+			if isPack {
+				span.SetAttributes(attribute.Int("palletizer.carton_count", cartonCount(req)))
+			}
+
+			resp, err := next(req.WithContext(ctx))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return resp, err
+			}
+			span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+			if isPack {
+				span.SetAttributes(attribute.Int("palletizer.pallet_count", palletCount(resp)))
+			}
+			return resp, nil
+		}
+	}
+}
+
+// cartonCount peeks at a Pack request's buffered body to count cartons,
+// using GetBody so the actual request body is left untouched. It
+// transparently ungzips the body first if the client compressed it (see
+// client.WithCompression) — the Content-Encoding header is already set by
+// the time middleware sees the request.
+func cartonCount(req *http.Request) int {
+	if req.GetBody == nil {
+		return 0
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return 0
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return 0
+	}
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		if data, err = gunzip(data); err != nil {
+			return 0
+		}
+	}
+
+	var packReq client.PackingRequest
+	if err := json.Unmarshal(data, &packReq); err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, carton := range packReq.Cartons {
+		count += carton.Quantity
+	}
+	return count
+}
+
+// palletCount peeks at a Pack response body to count pallets, restoring
+// resp.Body afterward (still in its original, possibly gzip-compressed
+// form) so the client's own response handling downstream can still read
+// and decompress it.
+func palletCount(resp *http.Response) int {
+	if resp == nil || resp.Body == nil {
+		return 0
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return 0
+	}
+
+	decoded := data
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		if decoded, err = gunzip(data); err != nil {
+			return 0
+		}
+	}
+
+	var packResp client.PackingResponse
+	if err := json.Unmarshal(decoded, &packResp); err != nil {
+		return 0
+	}
+	return len(packResp.Pallets)
+}
+
+// gunzip decompresses gzip-encoded data.
+func gunzip(data []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return io.ReadAll(gz)
+}
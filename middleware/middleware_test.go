@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	client "github.com/palletizer-app/go-sdk"
+)
+
+func TestWithAPIKey(t *testing.T) {
+	var gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("X-API-Key")
+		json.NewEncoder(w).Encode(client.HealthResponse{Status: "healthy"})
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL)
+	c.Use(WithAPIKey("X-API-Key", "secret"))
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if gotKey != "secret" {
+		t.Errorf("expected X-API-Key 'secret', got %q", gotKey)
+	}
+}
+
+func TestWithLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(client.HealthResponse{Status: "healthy"})
+	}))
+	defer server.Close()
+
+	var buf strings.Builder
+	logger := log.New(&buf, "", 0)
+
+	c := client.New(server.URL)
+	c.Use(WithLogging(logger, nil))
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "/api/v1/health") {
+		t.Errorf("expected log to mention health path, got %q", buf.String())
+	}
+}
+
+func TestCartonCountHandlesGzippedBody(t *testing.T) {
+	payload, err := json.Marshal(client.PackingRequest{
+		Cartons: []client.Carton{{ID: "BOX001", Quantity: 7}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(payload); err != nil {
+		t.Fatalf("failed to gzip payload: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://example.invalid/api/v1/pack", bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Encoding", "gzip")
+
+	if got := cartonCount(req); got != 7 {
+		t.Errorf("expected cartonCount 7, got %d", got)
+	}
+}
+
+func TestPalletCountHandlesGzippedBody(t *testing.T) {
+	payload, err := json.Marshal(client.PackingResponse{
+		Pallets: []client.Pallet{{PalletID: 1}, {PalletID: 2}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(payload); err != nil {
+		t.Fatalf("failed to gzip payload: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	resp := &http.Response{
+		Header: http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:   io.NopCloser(bytes.NewReader(buf.Bytes())),
+	}
+
+	if got := palletCount(resp); got != 2 {
+		t.Errorf("expected palletCount 2, got %d", got)
+	}
+
+	restored, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to re-read resp.Body: %v", err)
+	}
+	if !bytes.Equal(restored, buf.Bytes()) {
+		t.Error("expected resp.Body to be restored to its original gzipped bytes")
+	}
+}
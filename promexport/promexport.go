@@ -0,0 +1,141 @@
+// Package promexport exposes Palletizer client metrics as Prometheus
+// collectors, so a service embedding the client can be scraped by an
+// existing Grafana/Loki-style monitoring stack without writing glue code.
+package promexport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/palletizer-app/go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector implements prometheus.Collector by translating the fields of the
+// MetricsResponse last fetched from Client.Metrics into a counter and gauges.
+// The fetching itself is done by scrape, which Register calls periodically.
+type Collector struct {
+	client *client.Client
+
+	requestsTotal    *prometheus.Desc
+	avgComputationMs *prometheus.Desc
+	avgUtilPct       *prometheus.Desc
+	successRate      *prometheus.Desc
+	memoryAllocMB    *prometheus.Desc
+	goroutines       *prometheus.Desc
+	gcPauseSeconds   *prometheus.Desc
+	buildInfo        *prometheus.Desc
+
+	mu     sync.RWMutex
+	latest MetricsResponse
+}
+
+// MetricsResponse mirrors client.MetricsResponse; it is cached by Collector
+// between scrapes so Collect can run without blocking on the network.
+type MetricsResponse = client.MetricsResponse
+
+// NewCollector creates a Collector that reports metrics fetched from c.
+func NewCollector(c *client.Client) *Collector {
+	return &Collector{
+		client: c,
+		requestsTotal: prometheus.NewDesc(
+			"palletizer_requests_total", "Total number of packing requests served.", nil, nil,
+		),
+		avgComputationMs: prometheus.NewDesc(
+			"palletizer_avg_computation_ms", "Average packing computation time in milliseconds.", nil, nil,
+		),
+		avgUtilPct: prometheus.NewDesc(
+			"palletizer_avg_util_pct", "Average pallet utilization percentage.", nil, nil,
+		),
+		successRate: prometheus.NewDesc(
+			"palletizer_success_rate", "Fraction of packing requests that succeeded.", nil, nil,
+		),
+		memoryAllocMB: prometheus.NewDesc(
+			"palletizer_memory_alloc_mb", "Allocated heap memory in megabytes.", nil, nil,
+		),
+		goroutines: prometheus.NewDesc(
+			"palletizer_goroutines", "Number of running goroutines.", nil, nil,
+		),
+		gcPauseSeconds: prometheus.NewDesc(
+			"palletizer_gc_pause_seconds", "Duration of the most recent garbage collection pause.", nil, nil,
+		),
+		buildInfo: prometheus.NewDesc(
+			"palletizer_build_info", "Build information for the scraped Palletizer service.",
+			[]string{"go_version", "build_version", "build_time"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.requestsTotal
+	ch <- c.avgComputationMs
+	ch <- c.avgUtilPct
+	ch <- c.successRate
+	ch <- c.memoryAllocMB
+	ch <- c.goroutines
+	ch <- c.gcPauseSeconds
+	ch <- c.buildInfo
+}
+
+// Collect implements prometheus.Collector, emitting the most recently
+// scraped metrics. It never calls the Palletizer API itself; Register's
+// scraping goroutine keeps latest up to date.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	m := c.latest
+	c.mu.RUnlock()
+
+	ch <- prometheus.MustNewConstMetric(c.requestsTotal, prometheus.CounterValue, float64(m.TotalRequests))
+	ch <- prometheus.MustNewConstMetric(c.avgComputationMs, prometheus.GaugeValue, m.AverageTimeMs)
+	ch <- prometheus.MustNewConstMetric(c.avgUtilPct, prometheus.GaugeValue, m.AverageUtilPct)
+	ch <- prometheus.MustNewConstMetric(c.successRate, prometheus.GaugeValue, m.SuccessRate)
+	ch <- prometheus.MustNewConstMetric(c.memoryAllocMB, prometheus.GaugeValue, m.MemoryAllocMB)
+	ch <- prometheus.MustNewConstMetric(c.goroutines, prometheus.GaugeValue, float64(m.NumGoroutines))
+	ch <- prometheus.MustNewConstMetric(c.gcPauseSeconds, prometheus.GaugeValue, m.LastGCPauseMs/1000)
+	ch <- prometheus.MustNewConstMetric(c.buildInfo, prometheus.GaugeValue, 1, m.GoVersion, m.BuildVersion, m.BuildTime)
+}
+
+// scrape fetches the latest metrics from the client and caches them for the
+// next Collect call.
+func (c *Collector) scrape(ctx context.Context) error {
+	m, err := c.client.Metrics(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.latest = *m
+	c.mu.Unlock()
+	return nil
+}
+
+// Register creates a Collector for c, registers it with reg, scrapes
+// Client.Metrics once, and starts a goroutine that scrapes it every interval
+// until ctx is canceled. If that first scrape fails, the goroutine is still
+// started and the scrape error is returned along with the Collector. The
+// Collector is returned so callers can unregister it later.
+func Register(ctx context.Context, reg prometheus.Registerer, c *client.Client, interval time.Duration) (*Collector, error) {
+	collector := NewCollector(c)
+	if err := reg.Register(collector); err != nil {
+		return nil, err
+	}
+
+	err := collector.scrape(ctx)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = collector.scrape(ctx)
+			}
+		}
+	}()
+
+	return collector, err
+}
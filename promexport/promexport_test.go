@@ -0,0 +1,54 @@
+package promexport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	client "github.com/palletizer-app/go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCollectorCollect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(client.MetricsResponse{
+			TotalRequests:  42,
+			AverageTimeMs:  12.5,
+			AverageUtilPct: 88.0,
+			SuccessRate:    0.99,
+			MemoryAllocMB:  128.0,
+			NumGoroutines:  10,
+			LastGCPauseMs:  2.0,
+			GoVersion:      "go1.22",
+			BuildVersion:   "test",
+			BuildTime:      "2026-01-01",
+		})
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL)
+	collector := NewCollector(c)
+
+	if err := collector.scrape(context.Background()); err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+
+	ch := make(chan prometheus.Metric, 8)
+	collector.Collect(ch)
+	close(ch)
+
+	var found int
+	for m := range ch {
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			t.Fatalf("failed to write metric: %v", err)
+		}
+		found++
+	}
+	if found != 8 {
+		t.Errorf("expected 8 metrics, got %d", found)
+	}
+}